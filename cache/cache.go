@@ -0,0 +1,145 @@
+// Package cache wraps a githubv4.Client with an on-disk response cache so
+// that regenerating CONTRIBUTIONS.md doesn't re-issue every GraphQL query
+// (and burn API rate limit) on every run.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// DefaultTTL is how long a cached response is considered fresh.
+const DefaultTTL = 24 * time.Hour
+
+// Client is a githubv4.Client look-alike that serves Query from an on-disk
+// cache keyed on (query shape, variables) before falling back to the real
+// API.
+type Client struct {
+	inner   *githubv4.Client
+	dir     string
+	ttl     time.Duration
+	refresh bool
+}
+
+// New wraps inner with a cache rooted at dir (typically
+// $XDG_CACHE_HOME/tylitianrui-contrib/graphql). If refresh is true, cached
+// entries are always bypassed (but still refreshed on disk).
+func New(inner *githubv4.Client, dir string, ttl time.Duration, refresh bool) *Client {
+	return &Client{inner: inner, dir: dir, ttl: ttl, refresh: refresh}
+}
+
+// Query behaves like githubv4.Client.Query, transparently caching the
+// response on disk.
+func (c *Client) Query(ctx context.Context, q any, variables map[string]any) error {
+	key, err := cacheKey(q, variables)
+	if err != nil {
+		return c.inner.Query(ctx, q, variables)
+	}
+	path := filepath.Join(c.dir, key+".json")
+
+	if !c.refresh {
+		if data, ok := readFresh(path, c.ttl); ok {
+			return json.Unmarshal(data, q)
+		}
+	}
+
+	if err := c.inner.Query(ctx, q, variables); err != nil {
+		return err
+	}
+
+	if data, err := json.Marshal(q); err == nil {
+		_ = writeFile(path, data)
+	}
+	return nil
+}
+
+// Cursor is the pagination state remembered for a query so that a re-run
+// can resume from the last known page instead of starting over, in case
+// the process died mid-pagination. Nodes carries every node accumulated
+// across the pages already fetched (as JSON, shape owned by the caller), so
+// that resuming from EndCursor doesn't lose the data those earlier pages
+// contributed. It is only populated while HasNextPage is true; once
+// pagination finishes there's nothing left to resume.
+type Cursor struct {
+	EndCursor   string          `json:"endCursor"`
+	HasNextPage bool            `json:"hasNextPage"`
+	Nodes       json.RawMessage `json:"nodes,omitempty"`
+}
+
+// ResumeCursor returns the last remembered pagination cursor for
+// queryName, if one was saved and still indicates more pages were pending.
+// When the client was built with refresh, no cursor is resumed.
+func (c *Client) ResumeCursor(queryName string) (Cursor, bool) {
+	if c.refresh {
+		return Cursor{}, false
+	}
+	data, err := os.ReadFile(c.cursorPath(queryName))
+	if err != nil {
+		return Cursor{}, false
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return Cursor{}, false
+	}
+	return cursor, cursor.HasNextPage
+}
+
+// SaveCursor remembers the pagination cursor for queryName.
+func (c *Client) SaveCursor(queryName string, cursor Cursor) error {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return fmt.Errorf("marshal cursor: %w", err)
+	}
+	return writeFile(c.cursorPath(queryName), data)
+}
+
+func (c *Client) cursorPath(queryName string) string {
+	return filepath.Join(c.dir, "cursor-"+queryName+".json")
+}
+
+// cacheKey derives a stable cache key from the shape of q (which, for the
+// call sites in this tool, is always an anonymous struct tied 1:1 to a
+// specific GraphQL query) and the variables passed alongside it.
+//
+// TODO: once a REST fallback exists, key its entries on the request URL and
+// cache the response ETag so a revalidation can use If-None-Match.
+func cacheKey(q any, variables map[string]any) (string, error) {
+	varsJSON, err := json.Marshal(variables)
+	if err != nil {
+		return "", fmt.Errorf("marshal variables: %w", err)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%T\n", q)
+	h.Write(varsJSON)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func readFresh(path string, ttl time.Duration) ([]byte, bool) {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// contribConfig is the shape of .contrib.yaml: per-forge credentials and,
+// for self-hostable forges, the instance base URL.
+type contribConfig struct {
+	GitHub *gitHubConfig `yaml:"github"`
+	GitLab *gitLabConfig `yaml:"gitlab"`
+	Gitea  *giteaConfig  `yaml:"gitea"`
+}
+
+// gitHubConfig.Token is used only as a fallback when the GITHUB_TOKEN env
+// var is unset.
+type gitHubConfig struct {
+	Token string `yaml:"token"`
+}
+
+type gitLabConfig struct {
+	Token   string `yaml:"token"`
+	BaseURL string `yaml:"baseURL"`
+}
+
+type giteaConfig struct {
+	Token   string `yaml:"token"`
+	BaseURL string `yaml:"baseURL"`
+	User    string `yaml:"user"`
+}
+
+// loadConfig reads and parses .contrib.yaml. A missing file is not an
+// error: it means only the GitHub forge (configured via GITHUB_TOKEN) is
+// enabled, preserving this tool's original single-forge behavior.
+func loadConfig(path string) (*contribConfig, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &contribConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var cfg contribConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// githubCacheOpts controls the on-disk GraphQL response cache the GitHub
+// forge is built with.
+type githubCacheOpts struct {
+	Dir     string
+	TTL     time.Duration
+	Refresh bool
+}
+
+// enabledForges builds the list of Forges configured in cfg, plus the
+// GitHub forge authenticated with githubToken (GitHub is always enabled:
+// it's this tool's original and primary source).
+func enabledForges(cfg *contribConfig, githubToken string, githubCache githubCacheOpts) ([]Forge, error) {
+	forges := []Forge{NewGitHubForge(githubToken, githubCache.Dir, githubCache.TTL, githubCache.Refresh)}
+
+	if cfg.GitLab != nil {
+		forge, err := NewGitLabForge(cfg.GitLab.Token, cfg.GitLab.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("gitlab forge: %w", err)
+		}
+		forges = append(forges, forge)
+	}
+
+	if cfg.Gitea != nil {
+		forge, err := NewGiteaForge(cfg.Gitea.BaseURL, cfg.Gitea.Token, cfg.Gitea.User)
+		if err != nil {
+			return nil, fmt.Errorf("gitea forge: %w", err)
+		}
+		forges = append(forges, forge)
+	}
+
+	return forges, nil
+}
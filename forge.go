@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Contribution is a single repository a Forge reports the configured user
+// has contributed to.
+type Contribution struct {
+	NameWithOwner   string
+	StargazerCount  int
+	IsArchived      bool
+	IsFork          bool
+	PrimaryLanguage string
+	PushedAt        time.Time
+
+	// The following are only populated by forges that can cheaply aggregate
+	// merged pull/merge requests per repo (currently just GitHub).
+	MergedPRCount      int
+	FirstMergedAt      time.Time
+	LastMergedAt       time.Time
+	LatestMergedPRURL  string
+	MergedPullRequests []PullRequestSummary
+}
+
+// PullRequestSummary is one merged pull request, kept for --verbose output.
+type PullRequestSummary struct {
+	Number   int
+	URL      string
+	Title    string
+	MergedAt time.Time
+}
+
+// RepoMeta is repository metadata looked up independently of a contribution
+// listing, e.g. to backfill stars for a repo named in a static list.
+type RepoMeta struct {
+	StargazerCount  int
+	IsArchived      bool
+	IsFork          bool
+	PrimaryLanguage string
+	PushedAt        time.Time
+}
+
+// Forge is a source control hosting platform this tool can pull
+// contributions from. Each implementation is responsible for its own
+// authentication and pagination.
+type Forge interface {
+	// Name identifies the forge in log output and CONTRIBUTIONS.md section
+	// headers, e.g. "GitHub" or "GitLab".
+	Name() string
+
+	// ListContributions returns every repository the configured user has
+	// contributed to on this forge.
+	ListContributions(ctx context.Context) ([]Contribution, error)
+
+	// RepoMetadata looks up metadata for a single repository, identified in
+	// the forge's own "owner/name" convention.
+	RepoMetadata(ctx context.Context, ownerName string) (RepoMeta, error)
+}
+
+// splitOwnerName splits an "owner/name" repository identifier into its two
+// parts.
+func splitOwnerName(ownerName string) (owner, name string, err error) {
+	spl := strings.Split(ownerName, "/")
+	if len(spl) != 2 {
+		return "", "", fmt.Errorf("repo %s must have format 'owner/name'", ownerName)
+	}
+	return spl[0], spl[1], nil
+}
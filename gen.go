@@ -2,119 +2,171 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"sort"
-	"strings"
+	"time"
 
-	"github.com/shurcooL/githubv4"
-	"golang.org/x/oauth2"
+	"github.com/tylitianrui/tylitianrui/cache"
 )
 
-//go:generate go run gen.go
+//go:generate go run .
+
+// contribConfigPath is where per-forge credentials and instance URLs are
+// read from, in addition to the GITHUB_TOKEN/GERRIT_EMAIL env vars.
+const contribConfigPath = ".contrib.yaml"
+
+// defaultStaleAfter is how long since a repo's last push before it's
+// flagged as stale in the generated doc.
+const defaultStaleAfter = 2 * 365 * 24 * time.Hour
+
+// forgeBaseURL returns the web base URL to link repositories on for forge,
+// given the loaded config (needed for self-hosted GitLab/Gitea instances).
+func forgeBaseURL(forgeName string, cfg *contribConfig) string {
+	switch forgeName {
+	case "GitHub":
+		return "https://github.com"
+	case "GitLab":
+		if cfg.GitLab != nil && cfg.GitLab.BaseURL != "" {
+			return cfg.GitLab.BaseURL
+		}
+		return "https://gitlab.com"
+	case "Gitea":
+		if cfg.Gitea != nil {
+			return cfg.Gitea.BaseURL
+		}
+	}
+	return ""
+}
+
+// forgeScoreWeight scales a forge's star counts before its contributions are
+// sorted. Each forge's section is sorted on its own, so a weight applies
+// alike to every entry of that section and does not change its order
+// relative to other forges' sections.
+var forgeScoreWeight = map[string]float64{
+	"GitHub": 1,
+	"GitLab": 1,
+	"Gitea":  10,
+}
+
+func contributionScore(forgeName string, c Contribution) int {
+	weight := forgeScoreWeight[forgeName]
+	if weight == 0 {
+		weight = 1
+	}
+	return int(float64(c.StargazerCount) * weight)
+}
 
-// googleSourceGitHub holds mapping of
-// a Go Google Git repository name https://go.googlesource.com/<GoogleSourceRepo>
-// to GitHub owner name https://github.com/<GitHubOwnerName>.
-type googleSourceGitHub struct {
-	GoogleSourceRepo string
-	GitHubOwnerName  string
+// repoBadges returns the markers appended after a repository's link, e.g.
+// " (archived) (stale: last push 2021-03-14)".
+func repoBadges(c Contribution, staleAfter time.Duration) string {
+	var badges string
+	if c.IsArchived {
+		badges += " (archived)"
+	}
+	if c.IsFork {
+		badges += " (fork)"
+	}
+	if !c.PushedAt.IsZero() && time.Since(c.PushedAt) > staleAfter {
+		badges += fmt.Sprintf(" (stale: last push %s)", c.PushedAt.Format("2006-01-02"))
+	}
+	return badges
 }
 
-// googleGitHubRepos are Go Google Git repositories I have ever contributed to.
-var googleGitHubRepos = []googleSourceGitHub{
-	{"build", "golang/build"},
-	{"go", "golang/go"},
-	{"net", "golang/net"},
-	{"mod", "golang/mod"},
-	{"protobuf", "protocolbuffers/protobuf-go"},
-	{"tools", "golang/tools"},
-	{"text", "golang/text"},
-	{"vulndb", "golang/vulndb"},
-	{"website", "golang/website"},
+// formatStarCount renders a star count the way GitHub's UI does, e.g.
+// 12345 -> "12.3k".
+func formatStarCount(n int) string {
+	if n < 1000 {
+		return fmt.Sprintf("%d", n)
+	}
+	return fmt.Sprintf("%.1fk", float64(n)/1000)
 }
 
-// additionalGitHubRepos holds GitHub repositories I have contributed to with
-// label 'Closed'. Pull requests marked as "Closed" but commits from them moved
-// to repo's main branch. This happens when a main repository is in Gerrit
-// and GitHub is a mirror.
-var additionalGitHubRepos = []string{
-	"cue-lang/cue", // https://review.gerrithub.io/q/project:cue-lang%252Fcue
-	"cognitedata/cognite-sdk-python",
+// contributionSummary renders the "⭐ 12.3k · 7 merged PRs (2019→2024) ·
+// [latest](url)" suffix that follows the "— " of a single contribution line.
+func contributionSummary(c Contribution) string {
+	summary := fmt.Sprintf("⭐ %s", formatStarCount(c.StargazerCount))
+	if c.MergedPRCount > 0 {
+		summary += fmt.Sprintf(" · %d merged PRs (%d→%d) · [latest](%s)",
+			c.MergedPRCount, c.FirstMergedAt.Year(), c.LastMergedAt.Year(), c.LatestMergedPRURL)
+	}
+	return summary
 }
 
 func main() {
+	staleAfter := flag.Duration("stale-after", defaultStaleAfter, "consider a repo stale if its last push is older than this")
+	hideArchived := flag.Bool("hide-archived", false, "omit archived repos from the generated doc")
+	verbose := flag.Bool("verbose", false, "list every merged pull request per repo instead of a one-line summary")
+	refresh := flag.Bool("refresh", false, "bypass the GraphQL response cache and re-fetch everything")
+	flag.Parse()
+
+	gerritEmail := os.Getenv("GERRIT_EMAIL")
+	if gerritEmail == "" {
+		log.Fatal("env variable 'GERRIT_EMAIL' must be non-empty")
+	}
+
+	cfg, err := loadConfig(contribConfigPath)
+	if err != nil {
+		log.Fatalf("Failed to load %s: %v\n", contribConfigPath, err)
+	}
+
 	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" && cfg.GitHub != nil {
+		token = cfg.GitHub.Token
+	}
 	if token == "" {
-		log.Fatal("env variable 'GITHUB_TOKEN' must be non-empty")
+		log.Fatal("env variable 'GITHUB_TOKEN' or github.token in " + contribConfigPath + " must be non-empty")
 	}
 
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	httpClient := oauth2.NewClient(context.Background(), src)
-	client := githubv4.NewClient(httpClient)
+	cacheDir, err := cacheBaseDir()
+	if err != nil {
+		log.Fatalf("Failed to determine cache directory: %v\n", err)
+	}
 
-	allPullRequests, err := PullRequests(context.Background(), client)
+	forges, err := enabledForges(cfg, token, githubCacheOpts{
+		Dir:     cacheDir,
+		TTL:     cache.DefaultTTL,
+		Refresh: *refresh,
+	})
 	if err != nil {
-		log.Fatalf("Failed to get merged pull requests: %v\n", err)
+		log.Fatalf("Failed to set up forges: %v\n", err)
 	}
-	log.Printf("Total pull request: %d\n", len(allPullRequests))
 
-	repositoryStars := map[string]int{}
-	for _, pr := range allPullRequests {
-		ownerName := string(pr.Node.Repository.NameWithOwner)
-		if ownRepo(ownerName) {
-			log.Printf("Skipping own repo: %s\n", ownerName)
-			continue
-		}
-		if !pr.Node.Merged {
-			log.Printf("Skipping not merged repo: %s\n", ownerName)
-			continue
-		}
+	ctx := context.Background()
 
-		repositoryStars[ownerName] = int(pr.Node.Repository.StargazerCount)
+	gerritCLCounts, err := GerritMergedCLCounts(ctx, gerritEmail)
+	if err != nil {
+		log.Fatalf("Failed to get Gerrit merged CL counts: %v\n", err)
 	}
+	log.Printf("Total Gerrit projects: %d\n", len(gerritCLCounts))
 
-	for _, googleGithub := range googleGitHubRepos {
-		ownerName := googleGithub.GitHubOwnerName
-		starsCount, err := RepositoryStarsCount(context.Background(), client, ownerName)
-		if err != nil {
-			log.Printf("Failed to get repository %q stars: %v", ownerName, err)
-			starsCount = 1000
-		}
-		repositoryStars[ownerName] = starsCount
+	type forgeSection struct {
+		Name          string
+		BaseURL       string
+		Contributions []Contribution
 	}
 
-	for _, ownerName := range additionalGitHubRepos {
-		starsCount, err := RepositoryStarsCount(context.Background(), client, ownerName)
+	sections := make([]forgeSection, 0, len(forges))
+	for _, forge := range forges {
+		contributions, err := forge.ListContributions(ctx)
 		if err != nil {
-			log.Printf("Failed to get repository %q stars: %v", ownerName, err)
-			starsCount = 100
+			log.Fatalf("Failed to list %s contributions: %v\n", forge.Name(), err)
 		}
-		repositoryStars[ownerName] = starsCount
-	}
+		log.Printf("%s: %d contributed repositories\n", forge.Name(), len(contributions))
 
-	type repository struct {
-		OwnerName string
-		StarCount int
-	}
+		sort.Slice(contributions, func(i, j int) bool {
+			return contributionScore(forge.Name(), contributions[i]) > contributionScore(forge.Name(), contributions[j])
+		})
 
-	repositories := make([]repository, 0, len(repositoryStars))
-	for ownerName, star := range repositoryStars {
-		repositories = append(repositories, repository{
-			OwnerName: ownerName,
-			StarCount: star,
+		sections = append(sections, forgeSection{
+			Name:          forge.Name(),
+			BaseURL:       forgeBaseURL(forge.Name(), cfg),
+			Contributions: contributions,
 		})
 	}
 
-	sort.Slice(repositories, func(i, j int) bool {
-		return repositories[i].StarCount > repositories[j].StarCount
-	})
-
-	log.Printf("Total contributed projects: %d\n", len(repositories))
-
 	contribFile, err := os.Create("CONTRIBUTIONS.md")
 	if err != nil {
 		log.Fatalf("Create file: %v", err)
@@ -128,7 +180,7 @@ func main() {
 Code generated by gen.go; DO NOT EDIT.
 
 To update the doc run:
-GITHUB_TOKEN=<YOUR_TOKEN> go generate ./...
+GITHUB_TOKEN=<YOUR_TOKEN> GERRIT_EMAIL=<YOUR_EMAIL> go generate ./...
 -->
 
 # Open Source Projects I've Ever Contributed
@@ -137,96 +189,34 @@ GITHUB_TOKEN=<YOUR_TOKEN> go generate ./...
 	_, _ = contribFile.WriteString(`
 ## Go Google Git Repositories
 
-_links pointed to a log with my contributions_
+_sorted by merged CL count descending_
 
 `)
-	for _, repo := range googleGitHubRepos {
-		line := fmt.Sprintf("* [%[1]s](https://go.googlesource.com/%[1]s/+log?author=tylitianrui)\n", repo.GoogleSourceRepo)
+	for _, project := range sortedGerritProjects(gerritCLCounts) {
+		line := fmt.Sprintf("* [%[1]s](https://go.googlesource.com/%[1]s/+log?author=tylitianrui) — %[2]d merged CLs\n", project, gerritCLCounts[project])
 		_, _ = contribFile.WriteString(line)
 	}
 
-	_, _ = contribFile.WriteString(`
-## GitHub Projects
+	for _, section := range sections {
+		_, _ = contribFile.WriteString(fmt.Sprintf(`
+## %s Projects
 
 _sorted by stars descending_
 
-`)
-	for _, repo := range repositories {
-		line := fmt.Sprintf("* [%[1]s](https://github.com/%[1]s)\n", repo.OwnerName)
-		_, _ = contribFile.WriteString(line)
-	}
-}
-
-type edgePullRequest struct {
-	Node struct {
-		Repository struct {
-			NameWithOwner  githubv4.String
-			StargazerCount githubv4.Int
-		}
-		Merged githubv4.Boolean
-		Closed githubv4.Boolean
-	}
-}
-
-func PullRequests(ctx context.Context, client *githubv4.Client) ([]edgePullRequest, error) {
-	var pullRequests []edgePullRequest
-	variables := map[string]any{
-		"after": (*githubv4.String)(nil),
-	}
-
-	for {
-		var queryPullRequest struct {
-			Viewer struct {
-				PullRequests struct {
-					PageInfo struct {
-						EndCursor   githubv4.String
-						HasNextPage bool
-					}
-					TotalCount githubv4.Int
-					Edges      []edgePullRequest
-				} `graphql:"pullRequests(states: [MERGED, CLOSED], orderBy:{field: CREATED_AT, direction: ASC}, first:100, after: $after)"`
+`, section.Name))
+		for _, c := range section.Contributions {
+			if *hideArchived && c.IsArchived {
+				continue
+			}
+			line := fmt.Sprintf("* [%[1]s](%[2]s/%[1]s)%[3]s — %[4]s\n",
+				c.NameWithOwner, section.BaseURL, repoBadges(c, *staleAfter), contributionSummary(c))
+			_, _ = contribFile.WriteString(line)
+
+			if *verbose {
+				for _, pr := range c.MergedPullRequests {
+					_, _ = contribFile.WriteString(fmt.Sprintf("  * [%s](%s)\n", pr.Title, pr.URL))
+				}
 			}
 		}
-
-		if err := client.Query(ctx, &queryPullRequest, variables); err != nil {
-			return nil, fmt.Errorf("query: %w", err)
-		}
-		pullRequests = append(pullRequests, queryPullRequest.Viewer.PullRequests.Edges...)
-		if !queryPullRequest.Viewer.PullRequests.PageInfo.HasNextPage {
-			break
-		}
-		variables["after"] = queryPullRequest.Viewer.PullRequests.PageInfo.EndCursor
-	}
-
-	return pullRequests, nil
-}
-
-func RepositoryStarsCount(ctx context.Context, client *githubv4.Client, ownerName string) (int, error) {
-	spl := strings.Split(ownerName, "/")
-	if len(spl) != 2 {
-		return 0, fmt.Errorf("repo %s must have format 'owner/name'", ownerName)
-	}
-	owner, name := spl[0], spl[1]
-
-	variables := map[string]any{
-		"owner": githubv4.String(owner),
-		"name":  githubv4.String(name),
 	}
-
-	var queryRepository struct {
-		Repository struct {
-			StargazerCount githubv4.Int
-		} `graphql:"repository(owner: $owner, name: $name)"`
-	}
-
-	if err := client.Query(ctx, &queryRepository, variables); err != nil {
-		return 0, fmt.Errorf("query: %w", err)
-	}
-
-	return int(queryRepository.Repository.StargazerCount), nil
-}
-
-// ownRepo returns true if merged to my github.com/tylitianrui account.
-func ownRepo(ownerName string) bool {
-	return strings.HasPrefix(ownerName, "tylitianrui/")
 }
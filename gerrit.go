@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// gerritCacheTTL is how long a cached Gerrit changes response is considered
+// fresh before it is re-fetched.
+const gerritCacheTTL = 24 * time.Hour
+
+// gerritChange is the subset of a Gerrit ChangeInfo we care about.
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-info.
+type gerritChange struct {
+	Project string `json:"project"`
+}
+
+// GerritMergedCLCounts queries go.googlesource.com's Gerrit instance for
+// every merged change owned by email and returns the number of merged CLs per
+// project. Responses are cached under $XDG_CACHE_HOME/tylitianrui-contrib so
+// repeated runs don't re-hit the Gerrit REST API.
+func GerritMergedCLCounts(ctx context.Context, email string) (map[string]int, error) {
+	changes, err := fetchGerritChanges(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Project]++
+	}
+	return counts, nil
+}
+
+func fetchGerritChanges(ctx context.Context, email string) ([]gerritChange, error) {
+	cachePath, err := gerritCachePath(email)
+	if err == nil {
+		if changes, ok := readGerritCache(cachePath); ok {
+			return changes, nil
+		}
+	}
+
+	var changes []gerritChange
+	const pageSize = 500
+	start := 0
+	for {
+		page, more, err := fetchGerritChangesPage(ctx, email, start, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, page...)
+		if !more {
+			break
+		}
+		start += len(page)
+	}
+
+	if cachePath != "" {
+		if err := writeGerritCache(cachePath, changes); err != nil {
+			log.Printf("Failed to write Gerrit cache %q: %v", cachePath, err)
+		}
+	}
+
+	return changes, nil
+}
+
+func fetchGerritChangesPage(ctx context.Context, email string, start, pageSize int) ([]gerritChange, bool, error) {
+	q := url.Values{}
+	q.Set("q", fmt.Sprintf("owner:%s status:merged", email))
+	q.Set("S", fmt.Sprintf("%d", start))
+	q.Set("n", fmt.Sprintf("%d", pageSize))
+
+	reqURL := "https://go-review.googlesource.com/changes/?" + q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected status %s for %s", resp.Status, reqURL)
+	}
+
+	body, err := gerritStripXSSIPrefix(resp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var page []gerritChange
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, false, fmt.Errorf("decode gerrit response: %w", err)
+	}
+
+	// Gerrit marks the last change of a non-final page with _more_changes;
+	// re-decode loosely to pick it up without a bespoke struct per change.
+	var raw []map[string]any
+	moreChanges := false
+	if err := json.Unmarshal(body, &raw); err == nil && len(raw) > 0 {
+		if v, ok := raw[len(raw)-1]["_more_changes"].(bool); ok {
+			moreChanges = v
+		}
+	}
+
+	return page, moreChanges, nil
+}
+
+// gerritStripXSSIPrefix removes the ")]}'" XSSI-protection prefix Gerrit
+// prepends to every JSON response.
+func gerritStripXSSIPrefix(resp *http.Response) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	return bytes.TrimPrefix(buf.Bytes(), []byte(")]}'\n")), nil
+}
+
+func gerritCachePath(email string) (string, error) {
+	dir, err := cacheBaseDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gerrit-"+email+".json"), nil
+}
+
+// cacheBaseDir is $XDG_CACHE_HOME/tylitianrui-contrib, falling back to
+// ~/.cache/tylitianrui-contrib when XDG_CACHE_HOME is unset.
+func cacheBaseDir() (string, error) {
+	dir := os.Getenv("XDG_CACHE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("user home dir: %w", err)
+		}
+		dir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(dir, "tylitianrui-contrib"), nil
+}
+
+func readGerritCache(path string) ([]gerritChange, bool) {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > gerritCacheTTL {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var changes []gerritChange
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return nil, false
+	}
+
+	return changes, true
+}
+
+func writeGerritCache(path string, changes []gerritChange) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// sortedGerritProjects returns the projects in counts sorted by merged CL
+// count descending, breaking ties alphabetically.
+func sortedGerritProjects(counts map[string]int) []string {
+	projects := make([]string, 0, len(counts))
+	for project := range counts {
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if counts[projects[i]] != counts[projects[j]] {
+			return counts[projects[i]] > counts[projects[j]]
+		}
+		return projects[i] < projects[j]
+	})
+	return projects
+}
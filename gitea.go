@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// giteaForge is the Forge implementation backed by a Gitea instance,
+// selected by baseURL.
+type giteaForge struct {
+	client *gitea.Client
+	user   string
+}
+
+// NewGiteaForge builds a giteaForge authenticated with token against
+// baseURL, for the account named user.
+func NewGiteaForge(baseURL, token, user string) (*giteaForge, error) {
+	client, err := gitea.NewClient(baseURL, gitea.SetToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("new gitea client: %w", err)
+	}
+	return &giteaForge{client: client, user: user}, nil
+}
+
+func (f *giteaForge) Name() string { return "Gitea" }
+
+// ListContributions lists every repository that shows up in the user's
+// activity feed as a push or a merged pull request.
+func (f *giteaForge) ListContributions(_ context.Context) ([]Contribution, error) {
+	byOwnerName := map[string]Contribution{}
+
+	page := 1
+	for {
+		feeds, _, err := f.client.ListUserActivityFeeds(f.user, gitea.ListUserActivityFeedsOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: 50},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list user activity: %w", err)
+		}
+		if len(feeds) == 0 {
+			break
+		}
+
+		for _, feed := range feeds {
+			if feed.OpType != "commit_repo" && feed.OpType != "merge_pull_request" {
+				continue
+			}
+			if feed.Repo == nil {
+				continue
+			}
+
+			ownerName := feed.Repo.FullName
+			byOwnerName[ownerName] = Contribution{
+				NameWithOwner:  ownerName,
+				StargazerCount: int(feed.Repo.Stars),
+				IsFork:         feed.Repo.Fork,
+				PushedAt:       feed.Repo.Updated,
+			}
+		}
+
+		page++
+	}
+
+	contributions := make([]Contribution, 0, len(byOwnerName))
+	for _, c := range byOwnerName {
+		contributions = append(contributions, c)
+	}
+	return contributions, nil
+}
+
+func (f *giteaForge) RepoMetadata(_ context.Context, ownerName string) (RepoMeta, error) {
+	owner, name, err := splitOwnerName(ownerName)
+	if err != nil {
+		return RepoMeta{}, err
+	}
+
+	repo, _, err := f.client.GetRepo(owner, name)
+	if err != nil {
+		return RepoMeta{}, fmt.Errorf("get repo %s: %w", ownerName, err)
+	}
+
+	return RepoMeta{
+		StargazerCount: int(repo.Stars),
+		IsArchived:     repo.Archived,
+		IsFork:         repo.Fork,
+		PushedAt:       repo.Updated,
+	}, nil
+}
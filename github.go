@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"golang.org/x/oauth2"
+
+	"github.com/tylitianrui/tylitianrui/cache"
+)
+
+// googleSourceGitHub holds mapping of
+// a Go Google Git repository name https://go.googlesource.com/<GoogleSourceRepo>
+// to GitHub owner name https://github.com/<GitHubOwnerName>.
+type googleSourceGitHub struct {
+	GoogleSourceRepo string
+	GitHubOwnerName  string
+}
+
+// googleGitHubRepos are Go Google Git repositories I have ever contributed to,
+// kept here so their GitHub mirrors can still be starred/linked from the
+// GitHub section even though their commits are authored via Gerrit.
+var googleGitHubRepos = []googleSourceGitHub{
+	{"build", "golang/build"},
+	{"go", "golang/go"},
+	{"net", "golang/net"},
+	{"mod", "golang/mod"},
+	{"protobuf", "protocolbuffers/protobuf-go"},
+	{"tools", "golang/tools"},
+	{"text", "golang/text"},
+	{"vulndb", "golang/vulndb"},
+	{"website", "golang/website"},
+}
+
+// additionalGitHubRepos holds GitHub repositories I have contributed to
+// whose pull requests show up as "Closed" rather than "Merged", because the
+// GitHub repo is just a mirror of a Gerrit (or similar) project and my
+// commits landed through that instead. The GitHub API gives no way to tell
+// such a PR apart from one that was simply rejected, so these have to be
+// listed by hand rather than detected from the PR scan below.
+var additionalGitHubRepos = []string{
+	"cue-lang/cue", // https://review.gerrithub.io/q/project:cue-lang%252Fcue
+	"cognitedata/cognite-sdk-python",
+}
+
+// queryClient is the subset of githubv4.Client's surface this forge needs,
+// satisfied both by a plain *githubv4.Client and by *cache.Client.
+type queryClient interface {
+	Query(ctx context.Context, q any, variables map[string]any) error
+}
+
+// githubForge is the Forge implementation backed by the GitHub GraphQL v4
+// API.
+type githubForge struct {
+	client queryClient
+}
+
+// NewGitHubForge builds a githubForge authenticated with token. Responses
+// are cached under cacheDir/graphql for ttl; refresh forces cache bypass.
+func NewGitHubForge(token, cacheDir string, ttl time.Duration, refresh bool) *githubForge {
+	src := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+	httpClient := oauth2.NewClient(context.Background(), src)
+	raw := githubv4.NewClient(httpClient)
+	return &githubForge{client: cache.New(raw, filepath.Join(cacheDir, "graphql"), ttl, refresh)}
+}
+
+func (f *githubForge) Name() string { return "GitHub" }
+
+func (f *githubForge) ListContributions(ctx context.Context) ([]Contribution, error) {
+	contributedRepos, err := f.contributedRepositories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("contributed repositories: %w", err)
+	}
+
+	byOwnerName := map[string]Contribution{}
+	for _, repo := range contributedRepos {
+		ownerName := string(repo.NameWithOwner)
+		if ownRepo(ownerName) {
+			log.Printf("Skipping own repo: %s\n", ownerName)
+			continue
+		}
+		byOwnerName[ownerName] = Contribution{
+			NameWithOwner:   ownerName,
+			StargazerCount:  int(repo.StargazerCount),
+			IsArchived:      bool(repo.IsArchived),
+			IsFork:          bool(repo.IsFork),
+			PrimaryLanguage: string(repo.PrimaryLanguage.Name),
+			PushedAt:        repo.PushedAt.Time,
+		}
+	}
+
+	// The PR scan is kept as a fallback/merge source: it also catches repos
+	// that repositoriesContributedTo does not report, such as additional
+	// private contributions. It is also the only source for per-repo merged
+	// PR counts, since repositoriesContributedTo doesn't expose the pull
+	// requests themselves.
+	allPullRequests, err := f.pullRequests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pull requests: %w", err)
+	}
+	log.Printf("Total pull request: %d\n", len(allPullRequests))
+
+	for _, pr := range allPullRequests {
+		ownerName := string(pr.Node.Repository.NameWithOwner)
+		if ownRepo(ownerName) {
+			log.Printf("Skipping own repo: %s\n", ownerName)
+			continue
+		}
+		if !pr.Node.Merged {
+			log.Printf("Skipping not merged repo: %s\n", ownerName)
+			continue
+		}
+
+		c, ok := byOwnerName[ownerName]
+		if !ok {
+			c = Contribution{
+				NameWithOwner:  ownerName,
+				StargazerCount: int(pr.Node.Repository.StargazerCount),
+			}
+		}
+
+		mergedAt := pr.Node.MergedAt.Time
+		c.MergedPRCount++
+		if c.FirstMergedAt.IsZero() || mergedAt.Before(c.FirstMergedAt) {
+			c.FirstMergedAt = mergedAt
+		}
+		if mergedAt.After(c.LastMergedAt) {
+			c.LastMergedAt = mergedAt
+			c.LatestMergedPRURL = pr.Node.Url.String()
+		}
+		c.MergedPullRequests = append(c.MergedPullRequests, PullRequestSummary{
+			Number:   int(pr.Node.Number),
+			URL:      pr.Node.Url.String(),
+			Title:    string(pr.Node.Title),
+			MergedAt: mergedAt,
+		})
+
+		byOwnerName[ownerName] = c
+	}
+
+	for _, googleGithub := range googleGitHubRepos {
+		ownerName := googleGithub.GitHubOwnerName
+		meta, err := f.RepoMetadata(ctx, ownerName)
+		if err != nil {
+			log.Printf("Failed to get repository %q stars: %v", ownerName, err)
+			meta.StargazerCount = 1000
+		}
+
+		c := byOwnerName[ownerName]
+		c.NameWithOwner = ownerName
+		c.StargazerCount = meta.StargazerCount
+		c.IsArchived = meta.IsArchived
+		c.IsFork = meta.IsFork
+		c.PrimaryLanguage = meta.PrimaryLanguage
+		c.PushedAt = meta.PushedAt
+		byOwnerName[ownerName] = c
+	}
+
+	for _, ownerName := range additionalGitHubRepos {
+		meta, err := f.RepoMetadata(ctx, ownerName)
+		if err != nil {
+			log.Printf("Failed to get repository %q stars: %v", ownerName, err)
+			meta.StargazerCount = 100
+		}
+
+		c := byOwnerName[ownerName]
+		c.NameWithOwner = ownerName
+		c.StargazerCount = meta.StargazerCount
+		c.IsArchived = meta.IsArchived
+		c.IsFork = meta.IsFork
+		c.PrimaryLanguage = meta.PrimaryLanguage
+		c.PushedAt = meta.PushedAt
+		byOwnerName[ownerName] = c
+	}
+
+	contributions := make([]Contribution, 0, len(byOwnerName))
+	for _, c := range byOwnerName {
+		contributions = append(contributions, c)
+	}
+	return contributions, nil
+}
+
+func (f *githubForge) RepoMetadata(ctx context.Context, ownerName string) (RepoMeta, error) {
+	owner, name, err := splitOwnerName(ownerName)
+	if err != nil {
+		return RepoMeta{}, err
+	}
+
+	variables := map[string]any{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	var queryRepository struct {
+		Repository struct {
+			StargazerCount  githubv4.Int
+			IsArchived      githubv4.Boolean
+			IsFork          githubv4.Boolean
+			PushedAt        githubv4.DateTime
+			PrimaryLanguage struct {
+				Name githubv4.String
+			}
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	if err := f.client.Query(ctx, &queryRepository, variables); err != nil {
+		return RepoMeta{}, fmt.Errorf("query: %w", err)
+	}
+
+	return RepoMeta{
+		StargazerCount:  int(queryRepository.Repository.StargazerCount),
+		IsArchived:      bool(queryRepository.Repository.IsArchived),
+		IsFork:          bool(queryRepository.Repository.IsFork),
+		PrimaryLanguage: string(queryRepository.Repository.PrimaryLanguage.Name),
+		PushedAt:        queryRepository.Repository.PushedAt.Time,
+	}, nil
+}
+
+// contributionNode is a repository the viewer has contributed to, as
+// reported by the repositoriesContributedTo connection. Unlike a pull
+// request edge, this also covers repositories the viewer has pushed commits
+// to directly.
+type contributionNode struct {
+	NameWithOwner   githubv4.String
+	StargazerCount  githubv4.Int
+	IsArchived      githubv4.Boolean
+	IsFork          githubv4.Boolean
+	PushedAt        githubv4.DateTime
+	PrimaryLanguage struct {
+		Name githubv4.String
+	}
+}
+
+const contributedRepositoriesQueryName = "repositoriesContributedTo"
+
+// contributedRepositories returns every repository the viewer has
+// contributed to via a commit, a pull request, or a repository they own,
+// paginating through the viewer's repositoriesContributedTo connection.
+func (f *githubForge) contributedRepositories(ctx context.Context) ([]contributionNode, error) {
+	var contributions []contributionNode
+	variables := map[string]any{
+		"after": (*githubv4.String)(nil),
+	}
+
+	cc, cached := f.client.(*cache.Client)
+	if cached {
+		if cursor, ok := cc.ResumeCursor(contributedRepositoriesQueryName); ok {
+			variables["after"] = githubv4.String(cursor.EndCursor)
+			if len(cursor.Nodes) > 0 {
+				if err := json.Unmarshal(cursor.Nodes, &contributions); err != nil {
+					return nil, fmt.Errorf("unmarshal cached %s nodes: %w", contributedRepositoriesQueryName, err)
+				}
+			}
+		}
+	}
+
+	for {
+		var query struct {
+			Viewer struct {
+				RepositoriesContributedTo struct {
+					PageInfo struct {
+						EndCursor   githubv4.String
+						HasNextPage bool
+					}
+					Nodes []contributionNode
+				} `graphql:"repositoriesContributedTo(contributionTypes: [COMMIT, PULL_REQUEST, REPOSITORY], first: 100, after: $after)"`
+			}
+		}
+
+		if err := f.client.Query(ctx, &query, variables); err != nil {
+			return nil, fmt.Errorf("query: %w", err)
+		}
+		contributions = append(contributions, query.Viewer.RepositoriesContributedTo.Nodes...)
+
+		pageInfo := query.Viewer.RepositoriesContributedTo.PageInfo
+		if cached {
+			cursor := cache.Cursor{EndCursor: string(pageInfo.EndCursor), HasNextPage: pageInfo.HasNextPage}
+			if pageInfo.HasNextPage {
+				if nodesJSON, err := json.Marshal(contributions); err == nil {
+					cursor.Nodes = nodesJSON
+				}
+			}
+			_ = cc.SaveCursor(contributedRepositoriesQueryName, cursor)
+		}
+		if !pageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = pageInfo.EndCursor
+	}
+
+	return contributions, nil
+}
+
+type edgePullRequest struct {
+	Node struct {
+		Number     githubv4.Int
+		Url        githubv4.URI
+		Title      githubv4.String
+		MergedAt   githubv4.DateTime
+		Repository struct {
+			NameWithOwner  githubv4.String
+			StargazerCount githubv4.Int
+		}
+		Merged githubv4.Boolean
+		Closed githubv4.Boolean
+	}
+}
+
+const pullRequestsQueryName = "pullRequests"
+
+func (f *githubForge) pullRequests(ctx context.Context) ([]edgePullRequest, error) {
+	var pullRequests []edgePullRequest
+	variables := map[string]any{
+		"after": (*githubv4.String)(nil),
+	}
+
+	cc, cached := f.client.(*cache.Client)
+	if cached {
+		if cursor, ok := cc.ResumeCursor(pullRequestsQueryName); ok {
+			variables["after"] = githubv4.String(cursor.EndCursor)
+			if len(cursor.Nodes) > 0 {
+				if err := json.Unmarshal(cursor.Nodes, &pullRequests); err != nil {
+					return nil, fmt.Errorf("unmarshal cached %s nodes: %w", pullRequestsQueryName, err)
+				}
+			}
+		}
+	}
+
+	for {
+		var queryPullRequest struct {
+			Viewer struct {
+				PullRequests struct {
+					PageInfo struct {
+						EndCursor   githubv4.String
+						HasNextPage bool
+					}
+					TotalCount githubv4.Int
+					Edges      []edgePullRequest
+				} `graphql:"pullRequests(states: [MERGED, CLOSED], orderBy:{field: CREATED_AT, direction: ASC}, first:100, after: $after)"`
+			}
+		}
+
+		if err := f.client.Query(ctx, &queryPullRequest, variables); err != nil {
+			return nil, fmt.Errorf("query: %w", err)
+		}
+		pullRequests = append(pullRequests, queryPullRequest.Viewer.PullRequests.Edges...)
+
+		pageInfo := queryPullRequest.Viewer.PullRequests.PageInfo
+		if cached {
+			cursor := cache.Cursor{EndCursor: string(pageInfo.EndCursor), HasNextPage: pageInfo.HasNextPage}
+			if pageInfo.HasNextPage {
+				if nodesJSON, err := json.Marshal(pullRequests); err == nil {
+					cursor.Nodes = nodesJSON
+				}
+			}
+			_ = cc.SaveCursor(pullRequestsQueryName, cursor)
+		}
+		if !pageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = pageInfo.EndCursor
+	}
+
+	return pullRequests, nil
+}
+
+// ownRepo returns true if merged to my github.com/tylitianrui account.
+func ownRepo(ownerName string) bool {
+	return strings.HasPrefix(ownerName, "tylitianrui/")
+}
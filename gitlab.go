@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// gitlabForge is the Forge implementation backed by a GitLab instance
+// (gitlab.com or self-hosted, selected by baseURL).
+type gitlabForge struct {
+	client *gitlab.Client
+}
+
+// NewGitLabForge builds a gitlabForge authenticated with token against
+// baseURL (empty baseURL means gitlab.com).
+func NewGitLabForge(token, baseURL string) (*gitlabForge, error) {
+	opts := []gitlab.ClientOptionFunc{}
+	if baseURL != "" {
+		opts = append(opts, gitlab.WithBaseURL(baseURL))
+	}
+
+	client, err := gitlab.NewClient(token, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("new gitlab client: %w", err)
+	}
+	return &gitlabForge{client: client}, nil
+}
+
+func (f *gitlabForge) Name() string { return "GitLab" }
+
+// ListContributions lists every project the authenticated user has pushed
+// to or had a merge request merged in, by paging through their contribution
+// events.
+func (f *gitlabForge) ListContributions(ctx context.Context) ([]Contribution, error) {
+	byOwnerName := map[string]Contribution{}
+
+	opts := &gitlab.ListContributionEventsOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 100},
+	}
+	for {
+		events, resp, err := f.client.Events.ListCurrentUserContributionEvents(opts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("list contribution events: %w", err)
+		}
+
+		for _, event := range events {
+			if event.ActionName != "pushed" && event.ActionName != "merged" {
+				continue
+			}
+			if event.ProjectID == 0 {
+				continue
+			}
+
+			ownerName, meta, err := f.projectMeta(ctx, event.ProjectID)
+			if err != nil {
+				continue
+			}
+
+			byOwnerName[ownerName] = Contribution{
+				NameWithOwner:   ownerName,
+				StargazerCount:  meta.StargazerCount,
+				IsArchived:      meta.IsArchived,
+				IsFork:          meta.IsFork,
+				PrimaryLanguage: meta.PrimaryLanguage,
+				PushedAt:        meta.PushedAt,
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	contributions := make([]Contribution, 0, len(byOwnerName))
+	for _, c := range byOwnerName {
+		contributions = append(contributions, c)
+	}
+	return contributions, nil
+}
+
+func (f *gitlabForge) RepoMetadata(ctx context.Context, ownerName string) (RepoMeta, error) {
+	project, _, err := f.client.Projects.GetProject(ownerName, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return RepoMeta{}, fmt.Errorf("get project %s: %w", ownerName, err)
+	}
+
+	return RepoMeta{
+		StargazerCount: project.StarCount,
+		IsArchived:     project.Archived,
+		IsFork:         project.ForkedFromProject != nil,
+		PushedAt:       derefTime(project.LastActivityAt),
+	}, nil
+}
+
+func (f *gitlabForge) projectMeta(ctx context.Context, projectID int) (string, RepoMeta, error) {
+	project, _, err := f.client.Projects.GetProject(projectID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", RepoMeta{}, fmt.Errorf("get project %d: %w", projectID, err)
+	}
+
+	return project.PathWithNamespace, RepoMeta{
+		StargazerCount: project.StarCount,
+		IsArchived:     project.Archived,
+		IsFork:         project.ForkedFromProject != nil,
+		PushedAt:       derefTime(project.LastActivityAt),
+	}, nil
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}